@@ -0,0 +1,43 @@
+package configinator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// loadConfigFile reads the file at path and parses it into a generic
+// map[string]any. The format is chosen by autodetecting the file's
+// extension: .yaml/.yml, .toml, or .json.
+func loadConfigFile(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]any{}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if err = yaml.Unmarshal(data, &result); err != nil {
+			return nil, fmt.Errorf("configinator: unable to parse YAML config file %q: %w", path, err)
+		}
+	case ".toml":
+		if err = toml.Unmarshal(data, &result); err != nil {
+			return nil, fmt.Errorf("configinator: unable to parse TOML config file %q: %w", path, err)
+		}
+	case ".json":
+		if err = json.Unmarshal(data, &result); err != nil {
+			return nil, fmt.Errorf("configinator: unable to parse JSON config file %q: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("configinator: unsupported config file extension %q", filepath.Ext(path))
+	}
+
+	return result, nil
+}
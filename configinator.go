@@ -1,15 +1,48 @@
 package configinator
 
 import (
+	"errors"
 	"flag"
+	"fmt"
 	"os"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/adampresley/configinator/container"
 	"github.com/adampresley/configinator/env"
 )
 
+// ConfigFileFlagName is the command line flag used to point Behold at
+// a structured (YAML/TOML/JSON) configuration file.
+const ConfigFileFlagName = "config"
+
+// Options controls optional Behold behavior that isn't expressed
+// through struct tags.
+type Options struct {
+	// ConfigFile, when set, overrides any `-config` flag or
+	// `configfile` struct tag and is loaded as the structured
+	// configuration file source.
+	ConfigFile string
+
+	// EnvPrefix, when set, is prepended (with an underscore) to every
+	// field's `env` tag name(s), e.g. EnvPrefix "MYAPP" turns
+	// `env:"PORT"` into a lookup of "MYAPP_PORT".
+	EnvPrefix string
+}
+
+// ConfigError is part of Behold's returned error when one or more
+// fields tagged `required:"true"` were left at their zero value after
+// every config source (default, config file, env, .env, flag) was
+// applied, and no source other than the default supplied a value.
+type ConfigError struct {
+	MissingFields []string
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("configinator: missing required fields: %s", strings.Join(e.MissingFields, ", "))
+}
+
 /*
 Behold initializes a provided struct with values from defaults,
 environment, .env file, and flags. It does this by adding tags to your
@@ -24,22 +57,67 @@ or an environment variable named "HOST". If none of the above
 are provided then the value from 'default' is used.
 
 If an .env file is found that will be read and used.
+
+Behold returns an aggregated error (via errors.Join) for every field
+setup failure (an unsupported type, a bad config file, an invalid time
+or custom value, a missing required field, …). Fields with no `flag` tag, a
+`flag:"-"` tag, or that are unexported are silently skipped rather than
+treated as errors. See MustBehold for a panic-on-error wrapper.
+*/
+func Behold(config any) error {
+	return BeholdWithOptions(config, Options{})
+}
+
+// MustBehold behaves like Behold but panics instead of returning an
+// error, for callers that prefer to fail fast at startup rather than
+// handle the error themselves.
+func MustBehold(config any) {
+	if err := Behold(config); err != nil {
+		panic(err)
+	}
+}
+
+/*
+BeholdWithOptions behaves like Behold but accepts Options for behavior
+that struct tags can't express, such as pointing at an explicit
+structured config file:
+
+	err := BeholdWithOptions(&config, Options{ConfigFile: "config.yaml"})
+
+A structured config file can also be discovered via a `configfile`
+struct tag or a `-config` command line flag. Precedence, lowest to
+highest, is: default, config file, env, .env file, flag.
+
+Options.EnvPrefix, when set, is prepended to every field's `env` tag
+name(s). Fields tagged `required:"true"` that are still at their zero
+value once every source has been applied, and that no source other
+than the default supplied, are reported together as a *ConfigError.
+
+A time.Time field can set a `layout:"2006/01/02 15:04"` tag to parse
+with that exact format before falling back to the built-in formats.
+For each time.Time field, the last string value parsed (default, then
+config file, env, .env file, flag) decides: if it fails to parse, that
+is reported as part of the returned error, rather than silently
+resolving to the zero value.
 */
-func Behold(config any) {
+func BeholdWithOptions(config any, opts Options) error {
 	var (
-		err        error
 		index      int
 		containers []any
+		errs       []error
 	)
 
 	envFile := make(map[string]string)
+	configFile := make(map[string]any)
 
 	/*
 	 * If we have an environment file, load it
 	 */
 	if env.FileExists(".env") {
+		var err error
+
 		if envFile, err = env.ReadFile(".env"); err != nil {
-			panic(err)
+			return err
 		}
 	}
 
@@ -47,15 +125,38 @@ func Behold(config any) {
 	 * Read the type info for this struct
 	 */
 	t := reflect.TypeOf(config).Elem()
+	configValue := reflect.ValueOf(config).Elem()
 	containers = make([]any, t.NumField())
 
+	/*
+	 * Figure out where a structured config file, if any, might live.
+	 * The "-config" flag is registered now and read after flag.Parse()
+	 * below, alongside the per-field flags.
+	 */
+	tagConfigFilePath, _ := container.FindConfigFilePath(config)
+	var configFileFlag *string
+
+	if !flag.Parsed() {
+		configFileFlag = flag.String(ConfigFileFlagName, tagConfigFilePath, "Path to a structured (YAML/TOML/JSON) config file")
+	}
+
 	/*
 	 * First setup each field of the config struct. These are stored in "containers".
 	 * Each container knows the field type, value, env name, flag name, and adds
-	 * to the provided flag set.
+	 * to the provided flag set. configFile is empty at this point and is filled
+	 * in below, once the config file path is known; containers hold a reference
+	 * to the same map so they see it populated. A nil container (no error) means
+	 * the field was intentionally skipped, e.g. no `flag` tag, `flag:"-"`, or
+	 * unexported.
 	 */
 	for index = 0; index < t.NumField(); index++ {
-		containers[index], _ = container.New(config, index, envFile)
+		c, err := container.New(config, index, envFile, configFile, opts.EnvPrefix)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("configinator: field %s: %w", t.Field(index).Name, err))
+			continue
+		}
+
+		containers[index] = c
 	}
 
 	/*
@@ -65,41 +166,168 @@ func Behold(config any) {
 		flag.Parse()
 	}
 
+	/*
+	 * Resolve the config file path: explicit Options win, then the
+	 * -config flag, then the configfile struct tag.
+	 */
+	configFilePath := opts.ConfigFile
+
+	if configFilePath == "" && configFileFlag != nil && *configFileFlag != "" {
+		configFilePath = *configFileFlag
+	}
+
+	if configFilePath == "" {
+		configFilePath = tagConfigFilePath
+	}
+
+	if configFilePath != "" {
+		loaded, err := loadConfigFile(configFilePath)
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+
+		for key, value := range loaded {
+			configFile[key] = value
+		}
+	}
+
 	/*
 	 * Set the values in the config struct following precedence rules.
-	 * They already have default values set (precedence 1).
+	 * They already have default values set (precedence 1). Fields
+	 * tagged `required:"true"` that no source touched and that are
+	 * still at their zero value are collected and reported together.
 	 */
+	var missingRequired []string
+
 	for index = 0; index < t.NumField(); index++ {
 		c := containers[index]
+		if c == nil {
+			continue
+		}
+
+		provided := false
 
 		switch typedContainer := c.(type) {
 		case container.Container[bool]:
-			applyValueWithPrecedence(typedContainer)
+			provided = applyValueWithPrecedence(typedContainer)
 		case container.Container[int]:
-			applyValueWithPrecedence(typedContainer)
+			provided = applyValueWithPrecedence(typedContainer)
 		case container.Container[float64]:
-			applyValueWithPrecedence(typedContainer)
+			provided = applyValueWithPrecedence(typedContainer)
 		case container.Container[string]:
-			applyValueWithPrecedence(typedContainer)
+			provided = applyValueWithPrecedence(typedContainer)
 		case container.Container[time.Time]:
-			applyValueWithPrecedence(typedContainer)
+			provided = applyValueWithPrecedence(typedContainer)
+
+			if parser, ok := typedContainer.(container.TimeParser); ok {
+				if parseErr := parser.LastParseError(); parseErr != nil {
+					errs = append(errs, parseErr)
+				}
+			}
+		case container.Container[time.Duration]:
+			provided = applyValueWithPrecedence(typedContainer)
+		case container.Container[*time.Location]:
+			provided = applyValueWithPrecedence(typedContainer)
+		case container.Container[[]string]:
+			provided = applyValueWithPrecedence(typedContainer)
+		case container.Container[[]int]:
+			provided = applyValueWithPrecedence(typedContainer)
+		case container.Container[[]float64]:
+			provided = applyValueWithPrecedence(typedContainer)
+		case container.Container[[]bool]:
+			provided = applyValueWithPrecedence(typedContainer)
+		case container.Container[map[string]string]:
+			provided = applyValueWithPrecedence(typedContainer)
+		case container.CustomValueContainer:
+			var setErr error
+
+			provided, setErr = applyRawValueWithPrecedence(typedContainer)
+			if setErr != nil {
+				errs = append(errs, setErr)
+			}
+		}
+
+		if required, ok := t.Field(index).Tag.Lookup(container.TagRequired); ok && required == "true" {
+			if !provided && configValue.Field(index).IsZero() {
+				missingRequired = append(missingRequired, t.Field(index).Name)
+			}
 		}
 	}
+
+	if len(missingRequired) > 0 {
+		errs = append(errs, &ConfigError{MissingFields: missingRequired})
+	}
+
+	return errors.Join(errs...)
 }
 
-func applyValueWithPrecedence[T any](c container.Container[T]) {
-	// Environment variable (precedence 2)
+// applyValueWithPrecedence applies every source that supplies a value,
+// lowest precedence first so the highest one wins, and reports whether any source other than the
+// default supplied a value.
+func applyValueWithPrecedence[T any](c container.Container[T]) bool {
+	provided := false
+
+	// Structured config file (precedence 2)
+	if value, ok := c.GetConfigFileValue(); ok {
+		c.SetConfigValue(value)
+		provided = true
+	}
+
+	// Environment variable (precedence 3)
 	if value, ok := c.GetEnvValue(); ok {
 		c.SetConfigValue(value)
+		provided = true
 	}
 
-	// Environment file (precedence 3)
+	// Environment file (precedence 4)
 	if value, ok := c.GetEnvFileValue(); ok {
 		c.SetConfigValue(value)
+		provided = true
 	}
 
 	// Command line flag (highest precedence)
 	if value, ok := c.GetFlagValue(); ok {
 		c.SetConfigValue(value)
+		provided = true
 	}
+
+	return provided
+}
+
+// applyRawValueWithPrecedence mirrors applyValueWithPrecedence for
+// container.CustomValueContainer, whose SetConfigValue defers to a
+// user-defined UnmarshalConfig and so can fail. Every source is still
+// attempted even if an earlier one failed; their errors are joined.
+func applyRawValueWithPrecedence(c container.CustomValueContainer) (bool, error) {
+	var (
+		provided bool
+		errs     []error
+	)
+
+	set := func(value string, ok bool) {
+		if !ok {
+			return
+		}
+
+		if err := c.SetConfigValue(value); err != nil {
+			errs = append(errs, err)
+			return
+		}
+
+		provided = true
+	}
+
+	// Structured config file (precedence 2)
+	set(c.GetConfigFileValue())
+
+	// Environment variable (precedence 3)
+	set(c.GetEnvValue())
+
+	// Environment file (precedence 4)
+	set(c.GetEnvFileValue())
+
+	// Command line flag (highest precedence)
+	set(c.GetFlagValue())
+
+	return provided, errors.Join(errs...)
 }
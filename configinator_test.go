@@ -1,7 +1,9 @@
 package configinator
 
 import (
+	"errors"
 	"flag"
+	"fmt"
 	"os"
 	"testing"
 	"time"
@@ -269,6 +271,400 @@ func TestBeholdPrecedenceEnvOnly(t *testing.T) {
 	}
 }
 
+func TestBeholdConfigFile(t *testing.T) {
+	resetFlags()
+	resetEnv()
+
+	configContent := `host: configfile.com:9999
+port: 5555
+debug: true
+timeout: 12.5
+start: 2022-01-01T00:00:00Z`
+
+	err := os.WriteFile("testconfig.yaml", []byte(configContent), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create config file: %v", err)
+	}
+	defer os.Remove("testconfig.yaml")
+
+	putOldFlagsBack := fixFlags()
+	defer putOldFlagsBack()
+
+	config := &TestConfig{}
+	BeholdWithOptions(config, Options{ConfigFile: "testconfig.yaml"})
+
+	if config.Host != "configfile.com:9999" {
+		t.Errorf("Expected Host to be 'configfile.com:9999', got '%s'", config.Host)
+	}
+
+	if config.Port != 5555 {
+		t.Errorf("Expected Port to be 5555, got %d", config.Port)
+	}
+
+	expectedStart := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	if !config.StartTime.Equal(expectedStart) {
+		t.Errorf("Expected StartTime to be %v, got %v", expectedStart, config.StartTime)
+	}
+}
+
+func TestBeholdConfigFilePrecedenceBelowEnv(t *testing.T) {
+	resetFlags()
+	resetEnv()
+
+	err := os.WriteFile("testconfig.json", []byte(`{"host": "configfile.com:9999"}`), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create config file: %v", err)
+	}
+	defer os.Remove("testconfig.json")
+
+	os.Setenv("HOST", "env.com:2000")
+	defer resetEnv()
+
+	putOldFlagsBack := fixFlags()
+	defer putOldFlagsBack()
+
+	config := &TestConfig{}
+	BeholdWithOptions(config, Options{ConfigFile: "testconfig.json"})
+
+	// Environment variable should win over the config file
+	if config.Host != "env.com:2000" {
+		t.Errorf("Expected Host to be 'env.com:2000' (env precedence over config file), got '%s'", config.Host)
+	}
+}
+
+type Level int
+
+func (l *Level) UnmarshalConfig(raw string) error {
+	switch raw {
+	case "low":
+		*l = 1
+	case "medium":
+		*l = 2
+	case "high":
+		*l = 3
+	default:
+		return fmt.Errorf("invalid level: %s", raw)
+	}
+
+	return nil
+}
+
+func TestBeholdCustomSetterType(t *testing.T) {
+	resetFlags()
+	resetEnv()
+
+	type CustomConfig struct {
+		LogLevel Level         `flag:"level" env:"LOG_LEVEL" default:"low"`
+		Cooldown time.Duration `flag:"cooldown" env:"COOLDOWN" default:"5s"`
+	}
+
+	os.Setenv("LOG_LEVEL", "high")
+	defer resetEnv()
+
+	putOldFlagsBack := fixFlags()
+	defer putOldFlagsBack()
+
+	config := &CustomConfig{}
+	Behold(config)
+
+	if config.LogLevel != 3 {
+		t.Errorf("Expected LogLevel to be 3 (high), got %d", config.LogLevel)
+	}
+
+	if config.Cooldown != 5*time.Second {
+		t.Errorf("Expected Cooldown to be 5s, got %s", config.Cooldown)
+	}
+}
+
+func TestBeholdMultipleEnvNames(t *testing.T) {
+	resetFlags()
+	resetEnv()
+
+	type MultiEnvConfig struct {
+		DatabaseURL string `flag:"database-url" env:"DATABASE_URL,DB_URL,PGURL" default:""`
+	}
+
+	os.Setenv("DB_URL", "postgres://localhost/app")
+	defer os.Unsetenv("DB_URL")
+
+	putOldFlagsBack := fixFlags()
+	defer putOldFlagsBack()
+
+	config := &MultiEnvConfig{}
+	Behold(config)
+
+	if config.DatabaseURL != "postgres://localhost/app" {
+		t.Errorf("Expected DatabaseURL to be 'postgres://localhost/app', got '%s'", config.DatabaseURL)
+	}
+}
+
+func TestBeholdMultipleEnvNamesPrefersDeclaredOrder(t *testing.T) {
+	resetFlags()
+	resetEnv()
+
+	type MultiEnvConfig struct {
+		DatabaseURL string `flag:"database-url" env:"DATABASE_URL,DB_URL" default:""`
+	}
+
+	os.Setenv("DATABASE_URL", "postgres://primary/app")
+	os.Setenv("DB_URL", "postgres://fallback/app")
+	defer os.Unsetenv("DATABASE_URL")
+	defer os.Unsetenv("DB_URL")
+
+	putOldFlagsBack := fixFlags()
+	defer putOldFlagsBack()
+
+	config := &MultiEnvConfig{}
+	Behold(config)
+
+	if config.DatabaseURL != "postgres://primary/app" {
+		t.Errorf("Expected DatabaseURL to prefer DATABASE_URL, got '%s'", config.DatabaseURL)
+	}
+}
+
+func TestBeholdSliceFields(t *testing.T) {
+	resetFlags()
+	resetEnv()
+
+	type SliceConfig struct {
+		Tags  []string `flag:"tags" env:"TAGS" default:"a,b"`
+		Ports []int    `flag:"ports" env:"PORTS" default:"1;2" separator:";"`
+	}
+
+	os.Setenv("TAGS", "x,y,z")
+	defer os.Unsetenv("TAGS")
+
+	putOldFlagsBack := fixFlags()
+	defer putOldFlagsBack()
+
+	config := &SliceConfig{}
+	Behold(config)
+
+	if len(config.Tags) != 3 || config.Tags[0] != "x" || config.Tags[2] != "z" {
+		t.Errorf("Expected Tags to be [x y z], got %v", config.Tags)
+	}
+
+	if len(config.Ports) != 2 || config.Ports[0] != 1 || config.Ports[1] != 2 {
+		t.Errorf("Expected Ports to be [1 2], got %v", config.Ports)
+	}
+}
+
+func TestBeholdSliceFlagRepeated(t *testing.T) {
+	resetFlags()
+	resetEnv()
+
+	type SliceConfig struct {
+		Tags []string `flag:"tags" env:"TAGS" default:""`
+	}
+
+	oldArgs := os.Args
+	os.Args = []string{"test", "-tags=a", "-tags=b,c"}
+	defer func() { os.Args = oldArgs }()
+
+	config := &SliceConfig{}
+	Behold(config)
+
+	if len(config.Tags) != 3 || config.Tags[0] != "a" || config.Tags[1] != "b" || config.Tags[2] != "c" {
+		t.Errorf("Expected Tags to be [a b c], got %v", config.Tags)
+	}
+}
+
+func TestBeholdMapField(t *testing.T) {
+	resetFlags()
+	resetEnv()
+
+	type MapConfig struct {
+		Labels map[string]string `flag:"labels" env:"LABELS" default:""`
+	}
+
+	os.Setenv("LABELS", "FOO=1,BAR=2")
+	defer os.Unsetenv("LABELS")
+
+	putOldFlagsBack := fixFlags()
+	defer putOldFlagsBack()
+
+	config := &MapConfig{}
+	Behold(config)
+
+	if config.Labels["FOO"] != "1" || config.Labels["BAR"] != "2" {
+		t.Errorf("Expected Labels to be {FOO:1 BAR:2}, got %v", config.Labels)
+	}
+}
+
+func TestBeholdWithOptionsEnvPrefix(t *testing.T) {
+	resetFlags()
+	resetEnv()
+
+	type PrefixedConfig struct {
+		Port int `flag:"port" env:"PORT" default:"9000"`
+	}
+
+	os.Setenv("MYAPP_PORT", "8123")
+	defer os.Unsetenv("MYAPP_PORT")
+
+	putOldFlagsBack := fixFlags()
+	defer putOldFlagsBack()
+
+	config := &PrefixedConfig{}
+	BeholdWithOptions(config, Options{EnvPrefix: "MYAPP"})
+
+	if config.Port != 8123 {
+		t.Errorf("Expected Port to be 8123, got %d", config.Port)
+	}
+}
+
+func TestBeholdRequiredFieldMissingReturnsError(t *testing.T) {
+	resetFlags()
+	resetEnv()
+
+	type RequiredConfig struct {
+		APIKey string `flag:"api-key" env:"API_KEY" default:"" required:"true"`
+	}
+
+	putOldFlagsBack := fixFlags()
+	defer putOldFlagsBack()
+
+	config := &RequiredConfig{}
+	err := Behold(config)
+
+	if err == nil {
+		t.Fatal("Expected Behold to return an error on a missing required field")
+	}
+
+	var configErr *ConfigError
+	if !errors.As(err, &configErr) {
+		t.Errorf("Expected error to contain a *ConfigError, got %v", err)
+	}
+}
+
+func TestMustBeholdRequiredFieldMissingPanics(t *testing.T) {
+	resetFlags()
+	resetEnv()
+
+	type RequiredConfig struct {
+		APIKey string `flag:"api-key" env:"API_KEY" default:"" required:"true"`
+	}
+
+	putOldFlagsBack := fixFlags()
+	defer putOldFlagsBack()
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("Expected MustBehold to panic on a missing required field")
+		}
+	}()
+
+	config := &RequiredConfig{}
+	MustBehold(config)
+}
+
+func TestBeholdRequiredFieldProvidedDoesNotPanic(t *testing.T) {
+	resetFlags()
+	resetEnv()
+
+	type RequiredConfig struct {
+		APIKey string `flag:"api-key" env:"API_KEY" default:"" required:"true"`
+	}
+
+	os.Setenv("API_KEY", "secret")
+	defer os.Unsetenv("API_KEY")
+
+	putOldFlagsBack := fixFlags()
+	defer putOldFlagsBack()
+
+	config := &RequiredConfig{}
+	Behold(config)
+
+	if config.APIKey != "secret" {
+		t.Errorf("Expected APIKey to be 'secret', got '%s'", config.APIKey)
+	}
+}
+
+func TestBeholdTimeCustomLayout(t *testing.T) {
+	resetFlags()
+	resetEnv()
+
+	type LayoutConfig struct {
+		StartTime time.Time `flag:"start" env:"START_TIME" default:"2023/01/01 00:00" layout:"2006/01/02 15:04"`
+	}
+
+	os.Setenv("START_TIME", "2024/06/15 08:30")
+	defer os.Unsetenv("START_TIME")
+
+	putOldFlagsBack := fixFlags()
+	defer putOldFlagsBack()
+
+	config := &LayoutConfig{}
+	Behold(config)
+
+	expected, _ := time.Parse("2006/01/02 15:04", "2024/06/15 08:30")
+	if !config.StartTime.Equal(expected) {
+		t.Errorf("Expected StartTime to be %v, got %v", expected, config.StartTime)
+	}
+}
+
+func TestBeholdTimeInvalidValueReturnsError(t *testing.T) {
+	resetFlags()
+	resetEnv()
+
+	type LayoutConfig struct {
+		StartTime time.Time `flag:"start" env:"START_TIME" default:"2023-01-01"`
+	}
+
+	os.Setenv("START_TIME", "not-a-time")
+	defer os.Unsetenv("START_TIME")
+
+	putOldFlagsBack := fixFlags()
+	defer putOldFlagsBack()
+
+	config := &LayoutConfig{}
+	if err := Behold(config); err == nil {
+		t.Fatal("Expected Behold to return an error on an invalid time value")
+	}
+}
+
+func TestBeholdTimeInvalidDefaultReturnsError(t *testing.T) {
+	resetFlags()
+	resetEnv()
+
+	type LayoutConfig struct {
+		StartTime time.Time `flag:"start" env:"START_TIME" default:"not-a-real-time"`
+	}
+
+	putOldFlagsBack := fixFlags()
+	defer putOldFlagsBack()
+
+	config := &LayoutConfig{}
+	if err := Behold(config); err == nil {
+		t.Fatal("Expected Behold to return an error on an invalid default time value")
+	}
+}
+
+func TestMustBeholdTimeInvalidValuePanics(t *testing.T) {
+	resetFlags()
+	resetEnv()
+
+	type LayoutConfig struct {
+		StartTime time.Time `flag:"start" env:"START_TIME" default:"2023-01-01"`
+	}
+
+	os.Setenv("START_TIME", "not-a-time")
+	defer os.Unsetenv("START_TIME")
+
+	putOldFlagsBack := fixFlags()
+	defer putOldFlagsBack()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("Expected MustBehold to panic on an invalid time value")
+		}
+	}()
+
+	config := &LayoutConfig{}
+	MustBehold(config)
+}
+
 func TestBeholdAllDataTypes(t *testing.T) {
 	resetFlags()
 	resetEnv()
@@ -310,3 +706,53 @@ func TestBeholdAllDataTypes(t *testing.T) {
 		t.Errorf("Expected TimeVal to be %v, got %v", expectedTime, config.TimeVal)
 	}
 }
+
+func TestBeholdUnsupportedFieldTypeReturnsError(t *testing.T) {
+	resetFlags()
+	resetEnv()
+
+	type BadConfig struct {
+		Complex complex128 `flag:"complex" default:""`
+	}
+
+	putOldFlagsBack := fixFlags()
+	defer putOldFlagsBack()
+
+	config := &BadConfig{}
+	err := Behold(config)
+
+	if err == nil {
+		t.Fatal("Expected Behold to return an error for an unsupported field type")
+	}
+}
+
+func TestBeholdSkipsUnexportedAndIgnoredFields(t *testing.T) {
+	resetFlags()
+	resetEnv()
+
+	type SkipConfig struct {
+		Host     string `flag:"host" default:"localhost"`
+		ignoreMe string `flag:"ignore-me" default:"nope"`
+		Internal string `flag:"-" default:"nope"`
+	}
+
+	putOldFlagsBack := fixFlags()
+	defer putOldFlagsBack()
+
+	config := &SkipConfig{}
+	if err := Behold(config); err != nil {
+		t.Fatalf("Expected Behold to succeed, got %v", err)
+	}
+
+	if config.Host != "localhost" {
+		t.Errorf("Expected Host to be 'localhost', got '%s'", config.Host)
+	}
+
+	if config.ignoreMe != "" {
+		t.Errorf("Expected unexported field to be left untouched, got '%s'", config.ignoreMe)
+	}
+
+	if config.Internal != "" {
+		t.Errorf("Expected flag:\"-\" field to be left untouched, got '%s'", config.Internal)
+	}
+}
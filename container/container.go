@@ -1,6 +1,7 @@
 package container
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -16,6 +17,18 @@ const (
 	TagEnvName      string = "env"
 	TagDefaultValue string = "default"
 	TagDescription  string = "description"
+	TagConfigFile   string = "configfile"
+	TagSeparator    string = "separator"
+	TagKVSeparator  string = "kvseparator"
+	TagRequired     string = "required"
+	TagLayout       string = "layout"
+)
+
+// Default separators used when a field doesn't carry its own
+// separator/kvseparator tag.
+const (
+	DefaultSeparator   string = ","
+	DefaultKVSeparator string = "="
 )
 
 // Custom errors
@@ -33,8 +46,30 @@ var (
 	}
 )
 
+// Setter lets a user-defined type (a wrapper around net.IP or url.URL, a custom enum, …)
+// participate in config resolution by parsing its own raw string
+// value, for field types New doesn't already know how to build.
+type Setter interface {
+	UnmarshalConfig(raw string) error
+}
+
+var setterType = reflect.TypeOf((*Setter)(nil)).Elem()
+
+// CustomValueContainer resolves raw string values for fields whose
+// type implements Setter. Unlike Container[T], SetConfigValue can
+// fail, since it defers to the field's own UnmarshalConfig.
+type CustomValueContainer interface {
+	GetConfigFileValue() (string, bool)
+	GetEnvValue() (string, bool)
+	GetEnvFileValue() (string, bool)
+	GetFlagValue() (string, bool)
+	SetConfigValue(string) error
+	GetDefaultValue() string
+}
+
 // Generic container interface for type-safe value resolution
 type Container[T any] interface {
+	GetConfigFileValue() (T, bool)
 	GetEnvValue() (T, bool)
 	GetEnvFileValue() (T, bool)
 	GetFlagValue() (T, bool)
@@ -45,11 +80,12 @@ type Container[T any] interface {
 // Base container holds common fields and methods
 type baseContainer struct {
 	config       any
+	configFile   map[string]any
 	configValue  reflect.Value
 	defaultValue string
 	description  string
 	envFile      map[string]string
-	envName      string
+	envNames     []string
 	field        reflect.StructField
 	fieldName    string
 	fieldValue   reflect.Value
@@ -77,11 +113,135 @@ type StringContainer struct {
 }
 
 type TimeContainer struct {
+	baseContainer
+	flagValue      *string
+	layout         string
+	lastParseError error
+}
+
+// TimeParser is implemented by containers that can report why their
+// last parse attempt failed, so callers can distinguish a field that
+// was simply never set from one that was set to something invalid.
+type TimeParser interface {
+	LastParseError() error
+}
+
+type DurationContainer struct {
 	baseContainer
 	flagValue *string
 }
 
-func newBaseContainer(config any, index int, envFile map[string]string) (baseContainer, error) {
+type LocationContainer struct {
+	baseContainer
+	flagValue *string
+}
+
+// CustomContainer resolves fields whose type doesn't match one of the
+// built-in primitives but satisfies Setter, deferring the actual parse
+// to the field's UnmarshalConfig.
+type CustomContainer struct {
+	baseContainer
+	flagValue *string
+}
+
+// sliceFlagValue is a flag.Value that collects list values from either
+// a single separator-delimited flag ("-tags=a,b,c") or repeated flags
+// ("-tag=a -tag=b").
+type sliceFlagValue struct {
+	values    []string
+	separator string
+	set       bool
+}
+
+func (f *sliceFlagValue) String() string {
+	if f == nil {
+		return ""
+	}
+
+	return strings.Join(f.values, f.separator)
+}
+
+func (f *sliceFlagValue) Set(raw string) error {
+	f.set = true
+
+	for _, part := range strings.Split(raw, f.separator) {
+		if part != "" {
+			f.values = append(f.values, part)
+		}
+	}
+
+	return nil
+}
+
+// mapFlagValue is a flag.Value that collects key/value pairs from
+// either a single delimited flag ("-tag=a=1,b=2") or repeated flags
+// ("-tag=a=1 -tag=b=2").
+type mapFlagValue struct {
+	values      map[string]string
+	separator   string
+	kvSeparator string
+	set         bool
+}
+
+func (f *mapFlagValue) String() string {
+	if f == nil {
+		return ""
+	}
+
+	parts := make([]string, 0, len(f.values))
+	for key, value := range f.values {
+		parts = append(parts, key+f.kvSeparator+value)
+	}
+
+	return strings.Join(parts, f.separator)
+}
+
+func (f *mapFlagValue) Set(raw string) error {
+	f.set = true
+
+	if f.values == nil {
+		f.values = map[string]string{}
+	}
+
+	for _, pair := range strings.Split(raw, f.separator) {
+		if pair == "" {
+			continue
+		}
+
+		kv := strings.SplitN(pair, f.kvSeparator, 2)
+		if len(kv) != 2 {
+			continue
+		}
+
+		f.values[kv[0]] = kv[1]
+	}
+
+	return nil
+}
+
+// SliceContainer resolves []string, []int, []float64, and []bool
+// fields. Element parsing is supplied by the type-specific
+// constructor (NewStringSlice, NewIntSlice, …), and splitting of
+// env/env-file/default string values is controlled by the field's
+// `separator` tag (default ",").
+type SliceContainer[T any] struct {
+	baseContainer
+	separator string
+	flagValue *sliceFlagValue
+	parse     func(string) (T, bool)
+}
+
+// MapContainer resolves map[string]string fields. Entry splitting uses
+// the field's `separator` tag (default ",") and key/value splitting
+// uses `kvseparator` (default "=").
+type MapContainer struct {
+	baseContainer
+	separator   string
+	kvSeparator string
+	flagValue   *mapFlagValue
+}
+
+func newBaseContainer(config any, index int, envFile map[string]string, configFile map[string]any, envPrefix string) (baseContainer, error) {
 	var (
 		hasFlag  bool
 		flagName string
@@ -96,17 +256,18 @@ func newBaseContainer(config any, index int, envFile map[string]string) (baseCon
 
 	flagName, hasFlag = t.Field(index).Tag.Lookup(TagFlagName)
 
-	if !hasFlag {
+	if !hasFlag || flagName == "-" {
 		return baseContainer{}, ErrNoFlagName
 	}
 
 	return baseContainer{
 		config:       config,
+		configFile:   configFile,
 		configValue:  configValue,
 		defaultValue: t.Field(index).Tag.Get(TagDefaultValue),
 		description:  t.Field(index).Tag.Get(TagDescription),
 		envFile:      envFile,
-		envName:      t.Field(index).Tag.Get(TagEnvName),
+		envNames:     prefixEnvNames(envPrefix, parseEnvNames(t.Field(index).Tag.Get(TagEnvName))),
 		field:        t.Field(index),
 		fieldName:    t.Field(index).Name,
 		fieldValue:   configValue.Field(index),
@@ -114,8 +275,51 @@ func newBaseContainer(config any, index int, envFile map[string]string) (baseCon
 	}, nil
 }
 
-func NewBool(config any, index int, envFile map[string]string) (Container[bool], error) {
-	base, err := newBaseContainer(config, index, envFile)
+// parseEnvNames splits an `env` struct tag into its candidate names,
+// e.g. `env:"DATABASE_URL,DB_URL,PGURL"`. Names are tried in the order
+// given, so a field can be renamed without breaking old deployments.
+func parseEnvNames(tag string) []string {
+	if tag == "" {
+		return nil
+	}
+
+	parts := strings.Split(tag, ",")
+	names := make([]string, 0, len(parts))
+
+	for _, part := range parts {
+		if name := strings.TrimSpace(part); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// prefixEnvNames prepends prefix (and an underscore) to each name, for
+// BeholdWithOptions' Options.EnvPrefix. A field's env tag is otherwise
+// unaffected.
+func prefixEnvNames(prefix string, names []string) []string {
+	if prefix == "" || len(names) == 0 {
+		return names
+	}
+
+	result := make([]string, len(names))
+	for i, name := range names {
+		result[i] = prefix + "_" + name
+	}
+
+	return result
+}
+
+// configFileKeys returns the keys a container should try, in order,
+// when looking itself up in the structured config file map: its flag
+// name first, then each of its env names (with any EnvPrefix applied).
+func (c *baseContainer) configFileKeys() []string {
+	return append([]string{c.flagName}, c.envNames...)
+}
+
+func NewBool(config any, index int, envFile map[string]string, configFile map[string]any, envPrefix string) (Container[bool], error) {
+	base, err := newBaseContainer(config, index, envFile, configFile, envPrefix)
 	if err != nil {
 		return nil, err
 	}
@@ -130,8 +334,8 @@ func NewBool(config any, index int, envFile map[string]string) (Container[bool],
 	return result, nil
 }
 
-func NewInt(config any, index int, envFile map[string]string) (Container[int], error) {
-	base, err := newBaseContainer(config, index, envFile)
+func NewInt(config any, index int, envFile map[string]string, configFile map[string]any, envPrefix string) (Container[int], error) {
+	base, err := newBaseContainer(config, index, envFile, configFile, envPrefix)
 	if err != nil {
 		return nil, err
 	}
@@ -146,8 +350,8 @@ func NewInt(config any, index int, envFile map[string]string) (Container[int], e
 	return result, nil
 }
 
-func NewFloat64(config any, index int, envFile map[string]string) (Container[float64], error) {
-	base, err := newBaseContainer(config, index, envFile)
+func NewFloat64(config any, index int, envFile map[string]string, configFile map[string]any, envPrefix string) (Container[float64], error) {
+	base, err := newBaseContainer(config, index, envFile, configFile, envPrefix)
 	if err != nil {
 		return nil, err
 	}
@@ -162,8 +366,8 @@ func NewFloat64(config any, index int, envFile map[string]string) (Container[flo
 	return result, nil
 }
 
-func NewString(config any, index int, envFile map[string]string) (Container[string], error) {
-	base, err := newBaseContainer(config, index, envFile)
+func NewString(config any, index int, envFile map[string]string, configFile map[string]any, envPrefix string) (Container[string], error) {
+	base, err := newBaseContainer(config, index, envFile, configFile, envPrefix)
 	if err != nil {
 		return nil, err
 	}
@@ -178,46 +382,289 @@ func NewString(config any, index int, envFile map[string]string) (Container[stri
 	return result, nil
 }
 
-func NewTime(config any, index int, envFile map[string]string) (Container[time.Time], error) {
-	base, err := newBaseContainer(config, index, envFile)
+func NewTime(config any, index int, envFile map[string]string, configFile map[string]any, envPrefix string) (Container[time.Time], error) {
+	base, err := newBaseContainer(config, index, envFile, configFile, envPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	t := reflect.TypeOf(config).Elem()
+	result := &TimeContainer{baseContainer: base, layout: t.Field(index).Tag.Get(TagLayout)}
+
+	if !flag.Parsed() {
+		result.flagValue = flag.String(result.flagName, result.defaultValue, result.description)
+	}
+
+	result.SetConfigValue(result.GetDefaultValue())
+	return result, nil
+}
+
+func NewDuration(config any, index int, envFile map[string]string, configFile map[string]any, envPrefix string) (Container[time.Duration], error) {
+	base, err := newBaseContainer(config, index, envFile, configFile, envPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DurationContainer{baseContainer: base}
+
+	if !flag.Parsed() {
+		result.flagValue = flag.String(result.flagName, result.defaultValue, result.description)
+	}
+
+	result.SetConfigValue(result.GetDefaultValue())
+	return result, nil
+}
+
+func NewLocation(config any, index int, envFile map[string]string, configFile map[string]any, envPrefix string) (Container[*time.Location], error) {
+	base, err := newBaseContainer(config, index, envFile, configFile, envPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &LocationContainer{baseContainer: base}
+
+	if !flag.Parsed() {
+		result.flagValue = flag.String(result.flagName, result.defaultValue, result.description)
+	}
+
+	result.SetConfigValue(result.GetDefaultValue())
+	return result, nil
+}
+
+func NewCustom(config any, index int, envFile map[string]string, configFile map[string]any, envPrefix string) (CustomValueContainer, error) {
+	base, err := newBaseContainer(config, index, envFile, configFile, envPrefix)
 	if err != nil {
 		return nil, err
 	}
 
-	result := &TimeContainer{baseContainer: base}
+	result := &CustomContainer{baseContainer: base}
 
 	if !flag.Parsed() {
 		result.flagValue = flag.String(result.flagName, result.defaultValue, result.description)
 	}
 
+	if result.defaultValue != "" {
+		if err = result.SetConfigValue(result.GetDefaultValue()); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// fieldSeparator returns the field's `separator` tag value, or
+// DefaultSeparator when the tag is absent or empty.
+func fieldSeparator(config any, index int) string {
+	t := reflect.TypeOf(config).Elem()
+
+	if separator := t.Field(index).Tag.Get(TagSeparator); separator != "" {
+		return separator
+	}
+
+	return DefaultSeparator
+}
+
+// fieldKVSeparator returns the field's `kvseparator` tag value, or
+// DefaultKVSeparator when the tag is absent or empty.
+func fieldKVSeparator(config any, index int) string {
+	t := reflect.TypeOf(config).Elem()
+
+	if kvSeparator := t.Field(index).Tag.Get(TagKVSeparator); kvSeparator != "" {
+		return kvSeparator
+	}
+
+	return DefaultKVSeparator
+}
+
+func newSliceContainer[T any](config any, index int, envFile map[string]string, configFile map[string]any, envPrefix string, parse func(string) (T, bool)) (Container[[]T], error) {
+	base, err := newBaseContainer(config, index, envFile, configFile, envPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	separator := fieldSeparator(config, index)
+
+	result := &SliceContainer[T]{
+		baseContainer: base,
+		separator:     separator,
+		parse:         parse,
+	}
+
+	if !flag.Parsed() {
+		result.flagValue = &sliceFlagValue{separator: separator}
+		flag.Var(result.flagValue, result.flagName, result.description)
+	}
+
+	result.SetConfigValue(result.GetDefaultValue())
+	return result, nil
+}
+
+func NewStringSlice(config any, index int, envFile map[string]string, configFile map[string]any, envPrefix string) (Container[[]string], error) {
+	return newSliceContainer[string](config, index, envFile, configFile, envPrefix, func(raw string) (string, bool) {
+		return raw, true
+	})
+}
+
+func NewIntSlice(config any, index int, envFile map[string]string, configFile map[string]any, envPrefix string) (Container[[]int], error) {
+	return newSliceContainer[int](config, index, envFile, configFile, envPrefix, func(raw string) (int, bool) {
+		result, err := strconv.Atoi(raw)
+		return result, err == nil
+	})
+}
+
+func NewFloat64Slice(config any, index int, envFile map[string]string, configFile map[string]any, envPrefix string) (Container[[]float64], error) {
+	return newSliceContainer[float64](config, index, envFile, configFile, envPrefix, func(raw string) (float64, bool) {
+		result, err := strconv.ParseFloat(raw, 64)
+		return result, err == nil
+	})
+}
+
+func NewBoolSlice(config any, index int, envFile map[string]string, configFile map[string]any, envPrefix string) (Container[[]bool], error) {
+	return newSliceContainer[bool](config, index, envFile, configFile, envPrefix, func(raw string) (bool, bool) {
+		result, err := strconv.ParseBool(raw)
+		return result, err == nil
+	})
+}
+
+func NewMap(config any, index int, envFile map[string]string, configFile map[string]any, envPrefix string) (Container[map[string]string], error) {
+	base, err := newBaseContainer(config, index, envFile, configFile, envPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	separator := fieldSeparator(config, index)
+	kvSeparator := fieldKVSeparator(config, index)
+
+	result := &MapContainer{
+		baseContainer: base,
+		separator:     separator,
+		kvSeparator:   kvSeparator,
+	}
+
+	if !flag.Parsed() {
+		result.flagValue = &mapFlagValue{separator: separator, kvSeparator: kvSeparator}
+		flag.Var(result.flagValue, result.flagName, result.description)
+	}
+
 	result.SetConfigValue(result.GetDefaultValue())
 	return result, nil
 }
 
-func New(config any, index int, envFile map[string]string) (any, error) {
+// New builds the Container (or CustomValueContainer) for the field at
+// index, dispatching on its type. A field that is unexported, has no
+// `flag` tag, or is explicitly ignored with `flag:"-"` is reported as
+// (nil, nil): it isn't an error, it's a legitimate request to skip the
+// field entirely.
+func New(config any, index int, envFile map[string]string, configFile map[string]any, envPrefix string) (any, error) {
+	c, err := newField(config, index, envFile, configFile, envPrefix)
+	if err != nil {
+		if errors.Is(err, ErrNoFlagName) || errors.Is(err, ErrCantSet) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func newField(config any, index int, envFile map[string]string, configFile map[string]any, envPrefix string) (any, error) {
 	t := reflect.TypeOf(config).Elem()
 	fieldType := strings.ToLower(t.Field(index).Type.String())
 
 	switch fieldType {
 	case "bool":
-		return NewBool(config, index, envFile)
+		return NewBool(config, index, envFile, configFile, envPrefix)
 	case "int":
-		return NewInt(config, index, envFile)
+		return NewInt(config, index, envFile, configFile, envPrefix)
 	case "float64":
-		return NewFloat64(config, index, envFile)
+		return NewFloat64(config, index, envFile, configFile, envPrefix)
 	case "string":
-		return NewString(config, index, envFile)
+		return NewString(config, index, envFile, configFile, envPrefix)
 	case "time.time":
-		return NewTime(config, index, envFile)
+		return NewTime(config, index, envFile, configFile, envPrefix)
+	case "time.duration":
+		return NewDuration(config, index, envFile, configFile, envPrefix)
+	case "*time.location":
+		return NewLocation(config, index, envFile, configFile, envPrefix)
+	case "[]string":
+		return NewStringSlice(config, index, envFile, configFile, envPrefix)
+	case "[]int":
+		return NewIntSlice(config, index, envFile, configFile, envPrefix)
+	case "[]float64":
+		return NewFloat64Slice(config, index, envFile, configFile, envPrefix)
+	case "[]bool":
+		return NewBoolSlice(config, index, envFile, configFile, envPrefix)
+	case "map[string]string":
+		return NewMap(config, index, envFile, configFile, envPrefix)
 	default:
+		if reflect.PointerTo(t.Field(index).Type).Implements(setterType) {
+			return NewCustom(config, index, envFile, configFile, envPrefix)
+		}
+
+		if _, err := newBaseContainer(config, index, envFile, configFile, envPrefix); err != nil {
+			return nil, err
+		}
+
 		return nil, fmt.Errorf("unsupported field type: %s", fieldType)
 	}
 }
 
+// FindConfigFilePath scans config's struct tags for a `configfile` tag
+// and returns its value as the default path to a structured
+// configuration file. The first field carrying the tag wins.
+func FindConfigFilePath(config any) (string, bool) {
+	t := reflect.TypeOf(config).Elem()
+
+	for i := 0; i < t.NumField(); i++ {
+		if path, ok := t.Field(i).Tag.Lookup(TagConfigFile); ok && path != "" {
+			return path, true
+		}
+	}
+
+	return "", false
+}
+
+// configFileLookup fetches the raw value for the first of keys that is
+// present in m. Containers try their flag name before their env name,
+// mirroring how those names double as the config file's keys.
+func configFileLookup(m map[string]any, keys ...string) (any, bool) {
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+
+		if value, ok := m[key]; ok {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
 // BoolContainer methods
+func (c *BoolContainer) GetConfigFileValue() (bool, bool) {
+	if raw, ok := configFileLookup(c.configFile, c.configFileKeys()...); ok {
+		switch value := raw.(type) {
+		case bool:
+			return value, true
+		case string:
+			if result, err := strconv.ParseBool(value); err == nil {
+				return result, true
+			}
+		}
+	}
+
+	return false, false
+}
+
 func (c *BoolContainer) GetEnvValue() (bool, bool) {
-	value := os.Getenv(c.envName)
-	if value != "" {
+	for _, name := range c.envNames {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+
 		if result, err := strconv.ParseBool(value); err == nil {
 			return result, true
 		}
@@ -227,7 +674,12 @@ func (c *BoolContainer) GetEnvValue() (bool, bool) {
 }
 
 func (c *BoolContainer) GetEnvFileValue() (bool, bool) {
-	if value, ok := c.envFile[c.envName]; ok {
+	for _, name := range c.envNames {
+		value, ok := c.envFile[name]
+		if !ok {
+			continue
+		}
+
 		if result, err := strconv.ParseBool(value); err == nil {
 			return result, true
 		}
@@ -257,9 +709,32 @@ func (c *BoolContainer) GetDefaultValue() bool {
 }
 
 // IntContainer methods
+func (c *IntContainer) GetConfigFileValue() (int, bool) {
+	if raw, ok := configFileLookup(c.configFile, c.configFileKeys()...); ok {
+		switch value := raw.(type) {
+		case int:
+			return value, true
+		case int64:
+			return int(value), true
+		case float64:
+			return int(value), true
+		case string:
+			if result, err := strconv.Atoi(value); err == nil {
+				return result, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
 func (c *IntContainer) GetEnvValue() (int, bool) {
-	value := os.Getenv(c.envName)
-	if value != "" {
+	for _, name := range c.envNames {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+
 		if result, err := strconv.Atoi(value); err == nil {
 			return result, true
 		}
@@ -269,7 +744,12 @@ func (c *IntContainer) GetEnvValue() (int, bool) {
 }
 
 func (c *IntContainer) GetEnvFileValue() (int, bool) {
-	if value, ok := c.envFile[c.envName]; ok {
+	for _, name := range c.envNames {
+		value, ok := c.envFile[name]
+		if !ok {
+			continue
+		}
+
 		if result, err := strconv.Atoi(value); err == nil {
 			return result, true
 		}
@@ -299,9 +779,30 @@ func (c *IntContainer) GetDefaultValue() int {
 }
 
 // Float64Container methods
+func (c *Float64Container) GetConfigFileValue() (float64, bool) {
+	if raw, ok := configFileLookup(c.configFile, c.configFileKeys()...); ok {
+		switch value := raw.(type) {
+		case float64:
+			return value, true
+		case int:
+			return float64(value), true
+		case string:
+			if result, err := strconv.ParseFloat(value, 64); err == nil {
+				return result, true
+			}
+		}
+	}
+
+	return 0.0, false
+}
+
 func (c *Float64Container) GetEnvValue() (float64, bool) {
-	value := os.Getenv(c.envName)
-	if value != "" {
+	for _, name := range c.envNames {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+
 		if result, err := strconv.ParseFloat(value, 64); err == nil {
 			return result, true
 		}
@@ -311,7 +812,12 @@ func (c *Float64Container) GetEnvValue() (float64, bool) {
 }
 
 func (c *Float64Container) GetEnvFileValue() (float64, bool) {
-	if value, ok := c.envFile[c.envName]; ok {
+	for _, name := range c.envNames {
+		value, ok := c.envFile[name]
+		if !ok {
+			continue
+		}
+
 		if result, err := strconv.ParseFloat(value, 64); err == nil {
 			return result, true
 		}
@@ -341,18 +847,33 @@ func (c *Float64Container) GetDefaultValue() float64 {
 }
 
 // StringContainer methods
+func (c *StringContainer) GetConfigFileValue() (string, bool) {
+	if raw, ok := configFileLookup(c.configFile, c.configFileKeys()...); ok {
+		if value, ok := raw.(string); ok {
+			return value, true
+		}
+
+		return fmt.Sprintf("%v", raw), true
+	}
+
+	return "", false
+}
+
 func (c *StringContainer) GetEnvValue() (string, bool) {
-	value := os.Getenv(c.envName)
-	if value != "" {
-		return value, true
+	for _, name := range c.envNames {
+		if value := os.Getenv(name); value != "" {
+			return value, true
+		}
 	}
 
 	return "", false
 }
 
 func (c *StringContainer) GetEnvFileValue() (string, bool) {
-	if value, ok := c.envFile[c.envName]; ok {
-		return value, true
+	for _, name := range c.envNames {
+		if value, ok := c.envFile[name]; ok {
+			return value, true
+		}
 	}
 
 	return "", false
@@ -375,18 +896,34 @@ func (c *StringContainer) GetDefaultValue() string {
 }
 
 // TimeContainer methods
+func (c *TimeContainer) GetConfigFileValue() (time.Time, bool) {
+	if raw, ok := configFileLookup(c.configFile, c.configFileKeys()...); ok {
+		switch value := raw.(type) {
+		case time.Time:
+			return value, true
+		case string:
+			return c.parseTime(value), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
 func (c *TimeContainer) GetEnvValue() (time.Time, bool) {
-	value := os.Getenv(c.envName)
-	if value != "" {
-		return c.parseTime(value), true
+	for _, name := range c.envNames {
+		if value := os.Getenv(name); value != "" {
+			return c.parseTime(value), true
+		}
 	}
 
 	return time.Time{}, false
 }
 
 func (c *TimeContainer) GetEnvFileValue() (time.Time, bool) {
-	if value, ok := c.envFile[c.envName]; ok {
-		return c.parseTime(value), true
+	for _, name := range c.envNames {
+		if value, ok := c.envFile[name]; ok {
+			return c.parseTime(value), true
+		}
 	}
 
 	return time.Time{}, false
@@ -405,7 +942,7 @@ func (c *TimeContainer) SetConfigValue(value time.Time) {
 }
 
 func (c *TimeContainer) GetDefaultValue() time.Time {
-	if !c.isTime(c.defaultValue) {
+	if c.defaultValue == "" {
 		return time.Time{}
 	}
 
@@ -413,21 +950,421 @@ func (c *TimeContainer) GetDefaultValue() time.Time {
 }
 
 func (c *TimeContainer) parseTime(value string) time.Time {
+	if c.layout != "" {
+		if t, err := time.Parse(c.layout, value); err == nil {
+			c.lastParseError = nil
+			return t
+		}
+	}
+
 	for _, f := range timeFormats {
 		if t, err := time.Parse(f, value); err == nil {
+			c.lastParseError = nil
 			return t
 		}
 	}
 
+	c.lastParseError = fmt.Errorf("container: field %s: %q is not a valid time", c.fieldName, value)
 	return time.Time{}
 }
 
-func (c *TimeContainer) isTime(value string) bool {
-	for _, f := range timeFormats {
-		if _, err := time.Parse(f, value); err == nil {
-			return true
+// LastParseError reports why the most recent parseTime call failed,
+// or nil if it succeeded (or hasn't been attempted with a non-empty
+// value). GetDefaultValue sets it only for a non-empty default, since an
+// empty default means "unset", not "invalid".
+func (c *TimeContainer) LastParseError() error {
+	return c.lastParseError
+}
+
+// DurationContainer methods
+func (c *DurationContainer) GetConfigFileValue() (time.Duration, bool) {
+	if raw, ok := configFileLookup(c.configFile, c.configFileKeys()...); ok {
+		switch value := raw.(type) {
+		case int64:
+			return time.Duration(value), true
+		case int:
+			return time.Duration(value), true
+		case string:
+			return c.parseDuration(value)
 		}
 	}
 
-	return false
+	return 0, false
+}
+
+func (c *DurationContainer) GetEnvValue() (time.Duration, bool) {
+	for _, name := range c.envNames {
+		if value := os.Getenv(name); value != "" {
+			if result, ok := c.parseDuration(value); ok {
+				return result, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+func (c *DurationContainer) GetEnvFileValue() (time.Duration, bool) {
+	for _, name := range c.envNames {
+		if value, ok := c.envFile[name]; ok {
+			if result, ok := c.parseDuration(value); ok {
+				return result, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+func (c *DurationContainer) GetFlagValue() (time.Duration, bool) {
+	if c.flagValue != nil && *c.flagValue != c.defaultValue {
+		return c.parseDuration(*c.flagValue)
+	}
+
+	return 0, false
+}
+
+func (c *DurationContainer) SetConfigValue(value time.Duration) {
+	c.fieldValue.SetInt(int64(value))
+}
+
+func (c *DurationContainer) GetDefaultValue() time.Duration {
+	result, _ := c.parseDuration(c.defaultValue)
+	return result
+}
+
+func (c *DurationContainer) parseDuration(value string) (time.Duration, bool) {
+	result, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return result, true
+}
+
+// LocationContainer methods
+func (c *LocationContainer) GetConfigFileValue() (*time.Location, bool) {
+	if raw, ok := configFileLookup(c.configFile, c.configFileKeys()...); ok {
+		if value, ok := raw.(string); ok {
+			return c.parseLocation(value)
+		}
+	}
+
+	return nil, false
+}
+
+func (c *LocationContainer) GetEnvValue() (*time.Location, bool) {
+	for _, name := range c.envNames {
+		if value := os.Getenv(name); value != "" {
+			if result, ok := c.parseLocation(value); ok {
+				return result, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (c *LocationContainer) GetEnvFileValue() (*time.Location, bool) {
+	for _, name := range c.envNames {
+		if value, ok := c.envFile[name]; ok {
+			if result, ok := c.parseLocation(value); ok {
+				return result, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (c *LocationContainer) GetFlagValue() (*time.Location, bool) {
+	if c.flagValue != nil && *c.flagValue != c.defaultValue {
+		return c.parseLocation(*c.flagValue)
+	}
+
+	return nil, false
+}
+
+func (c *LocationContainer) SetConfigValue(value *time.Location) {
+	c.fieldValue.Set(reflect.ValueOf(value))
+}
+
+func (c *LocationContainer) GetDefaultValue() *time.Location {
+	result, ok := c.parseLocation(c.defaultValue)
+	if !ok {
+		return nil
+	}
+
+	return result
+}
+
+func (c *LocationContainer) parseLocation(value string) (*time.Location, bool) {
+	result, err := time.LoadLocation(value)
+	if err != nil {
+		return nil, false
+	}
+
+	return result, true
+}
+
+// CustomContainer methods
+func (c *CustomContainer) GetConfigFileValue() (string, bool) {
+	if raw, ok := configFileLookup(c.configFile, c.configFileKeys()...); ok {
+		if value, ok := raw.(string); ok {
+			return value, true
+		}
+
+		return fmt.Sprintf("%v", raw), true
+	}
+
+	return "", false
+}
+
+func (c *CustomContainer) GetEnvValue() (string, bool) {
+	for _, name := range c.envNames {
+		if value := os.Getenv(name); value != "" {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+func (c *CustomContainer) GetEnvFileValue() (string, bool) {
+	for _, name := range c.envNames {
+		if value, ok := c.envFile[name]; ok {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+func (c *CustomContainer) GetFlagValue() (string, bool) {
+	if c.flagValue != nil && *c.flagValue != c.GetDefaultValue() {
+		return *c.flagValue, true
+	}
+
+	return "", false
+}
+
+func (c *CustomContainer) GetDefaultValue() string {
+	return c.defaultValue
+}
+
+// SetConfigValue defers parsing to the field's own UnmarshalConfig.
+func (c *CustomContainer) SetConfigValue(value string) error {
+	setter, ok := c.fieldValue.Addr().Interface().(Setter)
+	if !ok {
+		return fmt.Errorf("field %s does not implement container.Setter", c.fieldName)
+	}
+
+	return setter.UnmarshalConfig(value)
+}
+
+// SliceContainer methods
+func (c *SliceContainer[T]) splitAndParse(raw string) ([]T, bool) {
+	parts := strings.Split(raw, c.separator)
+	result := make([]T, 0, len(parts))
+
+	for _, part := range parts {
+		if part == "" {
+			continue
+		}
+
+		if value, ok := c.parse(part); ok {
+			result = append(result, value)
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, false
+	}
+
+	return result, true
+}
+
+func (c *SliceContainer[T]) GetConfigFileValue() ([]T, bool) {
+	raw, ok := configFileLookup(c.configFile, c.configFileKeys()...)
+	if !ok {
+		return nil, false
+	}
+
+	switch value := raw.(type) {
+	case []any:
+		result := make([]T, 0, len(value))
+
+		for _, item := range value {
+			if parsed, ok := c.parse(fmt.Sprintf("%v", item)); ok {
+				result = append(result, parsed)
+			}
+		}
+
+		if len(result) == 0 {
+			return nil, false
+		}
+
+		return result, true
+	case string:
+		return c.splitAndParse(value)
+	}
+
+	return nil, false
+}
+
+func (c *SliceContainer[T]) GetEnvValue() ([]T, bool) {
+	for _, name := range c.envNames {
+		if value := os.Getenv(name); value != "" {
+			if result, ok := c.splitAndParse(value); ok {
+				return result, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (c *SliceContainer[T]) GetEnvFileValue() ([]T, bool) {
+	for _, name := range c.envNames {
+		if value, ok := c.envFile[name]; ok {
+			if result, ok := c.splitAndParse(value); ok {
+				return result, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (c *SliceContainer[T]) GetFlagValue() ([]T, bool) {
+	if c.flagValue == nil || !c.flagValue.set {
+		return nil, false
+	}
+
+	result := make([]T, 0, len(c.flagValue.values))
+
+	for _, raw := range c.flagValue.values {
+		if value, ok := c.parse(raw); ok {
+			result = append(result, value)
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, false
+	}
+
+	return result, true
+}
+
+func (c *SliceContainer[T]) SetConfigValue(value []T) {
+	slice := reflect.MakeSlice(c.fieldValue.Type(), len(value), len(value))
+
+	for i, item := range value {
+		slice.Index(i).Set(reflect.ValueOf(item))
+	}
+
+	c.fieldValue.Set(slice)
+}
+
+func (c *SliceContainer[T]) GetDefaultValue() []T {
+	if c.defaultValue == "" {
+		return nil
+	}
+
+	result, _ := c.splitAndParse(c.defaultValue)
+	return result
+}
+
+// MapContainer methods
+func (c *MapContainer) parseMap(raw string) (map[string]string, bool) {
+	result := map[string]string{}
+
+	for _, pair := range strings.Split(raw, c.separator) {
+		if pair == "" {
+			continue
+		}
+
+		kv := strings.SplitN(pair, c.kvSeparator, 2)
+		if len(kv) != 2 {
+			continue
+		}
+
+		result[kv[0]] = kv[1]
+	}
+
+	if len(result) == 0 {
+		return nil, false
+	}
+
+	return result, true
+}
+
+func (c *MapContainer) GetConfigFileValue() (map[string]string, bool) {
+	raw, ok := configFileLookup(c.configFile, c.configFileKeys()...)
+	if !ok {
+		return nil, false
+	}
+
+	switch value := raw.(type) {
+	case map[string]any:
+		result := make(map[string]string, len(value))
+		for key, item := range value {
+			result[key] = fmt.Sprintf("%v", item)
+		}
+
+		if len(result) == 0 {
+			return nil, false
+		}
+
+		return result, true
+	case string:
+		return c.parseMap(value)
+	}
+
+	return nil, false
+}
+
+func (c *MapContainer) GetEnvValue() (map[string]string, bool) {
+	for _, name := range c.envNames {
+		if value := os.Getenv(name); value != "" {
+			if result, ok := c.parseMap(value); ok {
+				return result, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (c *MapContainer) GetEnvFileValue() (map[string]string, bool) {
+	for _, name := range c.envNames {
+		if value, ok := c.envFile[name]; ok {
+			if result, ok := c.parseMap(value); ok {
+				return result, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (c *MapContainer) GetFlagValue() (map[string]string, bool) {
+	if c.flagValue == nil || !c.flagValue.set || len(c.flagValue.values) == 0 {
+		return nil, false
+	}
+
+	return c.flagValue.values, true
+}
+
+func (c *MapContainer) SetConfigValue(value map[string]string) {
+	c.fieldValue.Set(reflect.ValueOf(value))
+}
+
+func (c *MapContainer) GetDefaultValue() map[string]string {
+	if c.defaultValue == "" {
+		return nil
+	}
+
+	result, _ := c.parseMap(c.defaultValue)
+	return result
 }